@@ -4,20 +4,23 @@ package errgroup
 
 import (
 	"bytes"
+	"context"
 	"sync"
 )
 
 // MultiError allows returning a group of errors as one error.
 type MultiError []error
 
-// Error returns a concatenated string of all contained errors.
+// Error returns a concatenated string of all contained errors. An empty
+// MultiError returns the empty string, and a MultiError with a single error
+// returns that error's message.
 func (m MultiError) Error() string {
 	l := len(m)
 	if l == 0 {
-		panic("MultiError with no errors")
+		return ""
 	}
 	if l == 1 {
-		panic("MultiError with only 1 error")
+		return m[0].Error()
 	}
 	var b bytes.Buffer
 	b.WriteString("multiple errors: ")
@@ -30,6 +33,12 @@ func (m MultiError) Error() string {
 	return b.String()
 }
 
+// Unwrap returns the errors contained in m, allowing errors.Is and errors.As
+// to walk every collected error.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
 // NewMultiError returns nil if all input errors passed in are nil. Otherwise,
 // it coalesces all input errors into a single error instance. Useful for
 // code like this:
@@ -59,9 +68,43 @@ func NewMultiError(errs ...error) error {
 // The collected errors are never reset, so unlike a sync.WaitGroup, this Group
 // can only be used _once_. That is, you may only call Wait on it once.
 type Group struct {
-	wg     sync.WaitGroup
-	mu     sync.Mutex
-	errors MultiError
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	errors  MultiError
+	cancel  context.CancelFunc
+	sem     chan struct{}
+	waiting bool
+}
+
+// NewWithLimit returns a Group that allows at most n goroutines started via
+// Go to be in flight at once. A limit of n <= 0 means no limit, matching the
+// behavior of the zero value Group.
+func NewWithLimit(n int) *Group {
+	g := &Group{}
+	g.SetLimit(n)
+	return g
+}
+
+// SetLimit caps the number of goroutines started via Go that may be in
+// flight at once to n. A limit of n <= 0 removes the cap. SetLimit must not
+// be called concurrently with Go.
+func (g *Group) SetLimit(n int) {
+	if n <= 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// WithContext returns a new Group and an associated Context derived from
+// ctx. The derived Context is cancelled the first time a task submitted via
+// Go returns a non-nil error, or when Wait returns, whichever occurs first.
+// Unlike golang.org/x/sync/errgroup, all errors are still collected and
+// returned from Wait (as a MultiError when there is more than one) rather
+// than only the first.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
 }
 
 // Add adds delta, which may be negative. See sync.WaitGroup.Add documentation
@@ -70,18 +113,37 @@ func (g *Group) Add(delta int) {
 	g.wg.Add(delta)
 }
 
+// TryAdd behaves like Add, but refuses to do so once Wait has been entered,
+// returning false instead of racing with sync.WaitGroup's "Add called
+// concurrently with Wait" panic. This makes it safe to discover and add new
+// tasks from within already-running workers.
+func (g *Group) TryAdd(delta int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.waiting {
+		return false
+	}
+	g.wg.Add(delta)
+	return true
+}
+
 // Done decrements the Group counter.
 func (g *Group) Done() {
 	g.wg.Done()
 }
 
-// Error adds an error to return in Wait. `nil` errors are ignored.
+// Error adds an error to return in Wait. `nil` errors are ignored. If the
+// Group was created with WithContext, the first non-nil error cancels the
+// associated Context.
 func (g *Group) Error(e error) {
 	if e == nil {
 		return
 	}
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	if len(g.errors) == 0 && g.cancel != nil {
+		g.cancel()
+	}
 	g.errors = append(g.errors, e)
 }
 
@@ -91,13 +153,58 @@ func (g *Group) Finish(e error) {
 	g.Done()
 }
 
+// Go calls Add(1), then runs f in a new goroutine, reporting its returned
+// error (if any) before calling Done. If a limit was set via NewWithLimit or
+// SetLimit, Go blocks until a slot is available.
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+		}()
+		g.Finish(f())
+	}()
+}
+
+// TryGo behaves like Go, but refuses to start f once Wait has been entered,
+// returning false instead. See TryAdd for why this matters.
+func (g *Group) TryGo(f func() error) bool {
+	if !g.TryAdd(1) {
+		return false
+	}
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+		}()
+		g.Finish(f())
+	}()
+	return true
+}
+
 // Wait blocks until the Group counter is zero. If no errors were recorded, it
 // returns nil. If one error was recorded, it returns it as is. If more than
 // one error was recorded it returns a MultiError which is a slice of errors.
+// Once Wait has been called, TryAdd and TryGo refuse further work.
 func (g *Group) Wait() error {
+	g.mu.Lock()
+	g.waiting = true
+	g.mu.Unlock()
 	g.wg.Wait()
 	g.mu.Lock()
 	defer g.mu.Unlock()
+	if g.cancel != nil {
+		g.cancel()
+	}
 	errors := g.errors
 	l := len(errors)
 	if l == 0 {
@@ -0,0 +1,219 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupGo(t *testing.T) {
+	var g Group
+	for i := 0; i < 3; i++ {
+		g.Go(func() error { return nil })
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+}
+
+func TestGroupGoCollectsErrors(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	var g Group
+	g.Go(func() error { return nil })
+	g.Go(func() error { return errA })
+	g.Go(func() error { return errB })
+
+	err := g.Wait()
+	var multi MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Wait() = %v, want MultiError", err)
+	}
+	if len(multi) != 2 || !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("Wait() errors = %v, want [%v %v]", multi, errA, errB)
+	}
+}
+
+func TestWithContextCancelsOnFirstError(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+
+	errBoom := errors.New("boom")
+	errCancelled := errors.New("cancelled")
+	cancelled := make(chan struct{})
+
+	g.Go(func() error {
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+			return errCancelled
+		case <-time.After(time.Second):
+			t.Error("context was not cancelled after sibling error")
+			return nil
+		}
+	})
+	g.Go(func() error {
+		return errBoom
+	})
+
+	<-cancelled
+
+	err := g.Wait()
+	var multi MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Wait() = %v, want MultiError", err)
+	}
+	if len(multi) != 2 || !errors.Is(err, errBoom) || !errors.Is(err, errCancelled) {
+		t.Fatalf("Wait() errors = %v, want [%v %v]", multi, errBoom, errCancelled)
+	}
+}
+
+func TestNewWithLimitEnforcesCap(t *testing.T) {
+	const limit = 3
+	const tasks = 20
+
+	g := NewWithLimit(limit)
+	var inFlight, maxInFlight int64
+
+	for i := 0; i < tasks; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt64(&inFlight, 1)
+			for {
+				m := atomic.LoadInt64(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&inFlight, -1)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	if maxInFlight > limit {
+		t.Fatalf("max in-flight = %d, want <= %d", maxInFlight, limit)
+	}
+}
+
+func TestNewWithLimitZeroIsUnlimited(t *testing.T) {
+	const tasks = 50
+
+	g := NewWithLimit(0)
+	var inFlight, maxInFlight int64
+	release := make(chan struct{})
+
+	for i := 0; i < tasks; i++ {
+		g.Go(func() error {
+			n := atomic.AddInt64(&inFlight, 1)
+			for {
+				m := atomic.LoadInt64(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt64(&inFlight, -1)
+			return nil
+		})
+	}
+
+	for atomic.LoadInt64(&inFlight) < tasks {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	if maxInFlight != tasks {
+		t.Fatalf("max in-flight = %d, want %d (unlimited)", maxInFlight, tasks)
+	}
+}
+
+func TestMultiErrorIsAs(t *testing.T) {
+	errA := errors.New("a")
+	var errB *testError = &testError{msg: "b"}
+	multi := MultiError{errA, errB}
+
+	if !errors.Is(error(multi), errA) {
+		t.Fatal("errors.Is did not find errA in MultiError")
+	}
+
+	var target *testError
+	if !errors.As(error(multi), &target) {
+		t.Fatal("errors.As did not find *testError in MultiError")
+	}
+	if target != errB {
+		t.Fatalf("errors.As target = %v, want %v", target, errB)
+	}
+}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
+
+func TestMultiErrorErrorNoPanic(t *testing.T) {
+	if got := (MultiError{}).Error(); got != "" {
+		t.Fatalf("empty MultiError.Error() = %q, want %q", got, "")
+	}
+
+	errSingle := errors.New("single")
+	if got := (MultiError{errSingle}).Error(); got != errSingle.Error() {
+		t.Fatalf("single MultiError.Error() = %q, want %q", got, errSingle.Error())
+	}
+}
+
+func TestTryAddTryGoRefuseAfterWait(t *testing.T) {
+	var g Group
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+
+	if g.TryAdd(1) {
+		t.Fatal("TryAdd() = true after Wait, want false")
+	}
+	if g.TryGo(func() error { return nil }) {
+		t.Fatal("TryGo() = true after Wait, want false")
+	}
+}
+
+func TestTryGoConcurrentDiscoveryDuringWait(t *testing.T) {
+	var g Group
+	release := make(chan struct{})
+
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- g.Wait() }()
+
+	// Poll with a harmless TryAdd(0) until Wait has set the waiting flag,
+	// proving TryAdd/TryGo observe it while Wait is genuinely in flight
+	// (the pending task above keeps the WaitGroup counter above zero).
+	deadline := time.Now().Add(2 * time.Second)
+	for g.TryAdd(0) {
+		if time.Now().After(deadline) {
+			close(release)
+			t.Fatal("Wait never marked the Group as waiting")
+		}
+	}
+
+	if g.TryAdd(1) {
+		t.Error("TryAdd() = true while Wait is in flight, want false")
+	}
+	if g.TryGo(func() error { return nil }) {
+		t.Error("TryGo() = true while Wait is in flight, want false")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+}